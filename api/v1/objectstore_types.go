@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"github.com/cloudnative-pg/barman-cloud/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectStoreSpec defines the desired state of ObjectStore
+type ObjectStoreSpec struct {
+	// Configuration is the barman-cloud configuration used to archive backups
+	// and WALs into this object store, and to restore from it
+	Configuration api.BarmanObjectStoreConfiguration `json:"configuration"`
+
+	// Force allows bootstrapping a new cluster to archive into a destination
+	// that already holds backups under its server name, skipping the check
+	// for such existing backups. The check that the WAL archive destination
+	// is empty still applies.
+	// +optional
+	// +kubebuilder:default:=false
+	Force bool `json:"force,omitempty"`
+
+	// Encryption describes the client-side encryption applied to backups and
+	// WALs stored in this object store before restoring them
+	// +optional
+	Encryption *EncryptionConfiguration `json:"encryption,omitempty"`
+
+	// Hooks declares commands to run at specific points of the restore
+	// process
+	// +optional
+	Hooks *HooksConfiguration `json:"hooks,omitempty"`
+}
+
+// HookFailurePolicy controls whether a failing hook aborts the restore
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyAbort, the default, aborts the restore when a hook fails
+	HookFailurePolicyAbort HookFailurePolicy = "Abort"
+
+	// HookFailurePolicyContinue logs a failing hook and lets the restore proceed
+	HookFailurePolicyContinue HookFailurePolicy = "Continue"
+)
+
+// HooksConfiguration declares the commands to run at each point of the
+// restore flow where a user-provided hook can be executed
+type HooksConfiguration struct {
+	// PreDownload runs before the backup catalog is read and any data is
+	// transferred from the object store
+	// +optional
+	PreDownload *RestoreHookCommand `json:"preDownload,omitempty"`
+
+	// PostDatadir runs right after PGDATA (and the WAL volume, if any) have
+	// been restored, before WAL replay is configured
+	// +optional
+	PostDatadir *RestoreHookCommand `json:"postDatadir,omitempty"`
+
+	// PreWalConfig runs right before the restore_command is generated
+	// +optional
+	PreWalConfig *RestoreHookCommand `json:"preWalConfig,omitempty"`
+
+	// PostRestore runs right before the restore response is sent back
+	// +optional
+	PostRestore *RestoreHookCommand `json:"postRestore,omitempty"`
+
+	// FailurePolicy controls whether a failing hook aborts the restore
+	// +optional
+	// +kubebuilder:validation:Enum=Abort;Continue
+	// +kubebuilder:default:=Abort
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// RestoreHookCommand describes a single hook to execute
+type RestoreHookCommand struct {
+	// Command is the command (and arguments) to execute
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Image, when set, runs Command in a Job using this container image
+	// instead of running it in-process in the restore job
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// EnvFrom allows sourcing the hook's environment from secrets or
+	// config maps. Only honored when Image is set: in-process hooks inherit
+	// the restore job's own environment instead.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+}
+
+// EncryptionAlgorithm is a client-side encryption algorithm supported when
+// restoring from this object store
+type EncryptionAlgorithm string
+
+const (
+	// EncryptionAlgorithmAge selects age (https://age-encryption.org) decryption
+	EncryptionAlgorithmAge EncryptionAlgorithm = "age"
+
+	// EncryptionAlgorithmGPG selects GnuPG decryption
+	EncryptionAlgorithmGPG EncryptionAlgorithm = "gpg"
+)
+
+// EncryptionConfiguration describes how backups and WALs were encrypted
+// client-side before being uploaded to the object store, and where to find
+// the key needed to decrypt them back
+type EncryptionConfiguration struct {
+	// Algorithm is the client-side encryption algorithm that was used
+	// +kubebuilder:validation:Enum=age;gpg
+	Algorithm EncryptionAlgorithm `json:"algorithm"`
+
+	// KeySecret references the secret holding the private key used to
+	// decrypt the backup
+	KeySecret corev1.SecretKeySelector `json:"keySecret"`
+}
+
+// ObjectStoreStatus defines the observed state of ObjectStore
+type ObjectStoreStatus struct {
+	// ServerRecoveryWindow is the latest window of time for which recovery is
+	// known to be possible, computed by the last reconciliation
+	// +optional
+	ServerRecoveryWindow string `json:"serverRecoveryWindow,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ObjectStore is the Schema for the objectstores API
+type ObjectStore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ObjectStoreSpec   `json:"spec,omitempty"`
+	Status ObjectStoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectStoreList contains a list of ObjectStore
+type ObjectStoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectStore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ObjectStore{}, &ObjectStoreList{})
+}
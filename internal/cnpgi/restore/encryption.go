@@ -0,0 +1,188 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	barmancloudv1 "github.com/cloudnative-pg/plugin-barman-cloud/api/v1"
+)
+
+// encryptionKeyFileName is the name of the tmpfs-backed file holding the
+// decryption key material for the duration of the restore job
+const encryptionKeyFileName = "encryption.key"
+
+// decryptConcurrency bounds how many files decryptDataDir decrypts at once,
+// so the pass can take advantage of multiple cores on large PGDATA trees
+// without spawning one process per file all at once
+const decryptConcurrency = 4
+
+// encryptionConfig describes how the backup being restored was encrypted
+// client-side before being uploaded to the object store
+type encryptionConfig struct {
+	// Algorithm is one of barmancloudv1.EncryptionAlgorithmAge or
+	// barmancloudv1.EncryptionAlgorithmGPG
+	Algorithm barmancloudv1.EncryptionAlgorithm
+
+	// KeyFile is the path, on a tmpfs-backed volume, of the private key used
+	// to decrypt the backup
+	KeyFile string
+}
+
+// loadEncryptionConfig reads the ObjectStore's Encryption configuration and,
+// when one is declared, fetches the decryption key from the referenced
+// secret into a tmpfs-backed file. It returns nil when no encryption is
+// configured.
+func loadEncryptionConfig(
+	ctx context.Context,
+	typedClient client.Client,
+	namespace string,
+	encryption *barmancloudv1.EncryptionConfiguration,
+) (*encryptionConfig, error) {
+	if encryption == nil {
+		return nil, nil
+	}
+
+	switch encryption.Algorithm {
+	case barmancloudv1.EncryptionAlgorithmAge, barmancloudv1.EncryptionAlgorithmGPG:
+	default:
+		return nil, fmt.Errorf("unsupported encryption algorithm: %q", encryption.Algorithm)
+	}
+
+	var secret corev1.Secret
+	if err := typedClient.Get(
+		ctx,
+		types.NamespacedName{Namespace: namespace, Name: encryption.KeySecret.Name},
+		&secret,
+	); err != nil {
+		return nil, fmt.Errorf("while getting the encryption key secret %q: %w", encryption.KeySecret.Name, err)
+	}
+
+	keyMaterial, ok := secret.Data[encryption.KeySecret.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in secret %q", encryption.KeySecret.Key, encryption.KeySecret.Name)
+	}
+
+	keyFile := path.Join(RecoveryTemporaryDirectory, encryptionKeyFileName)
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyFile, keyMaterial, 0o600); err != nil {
+		return nil, fmt.Errorf("while writing the decryption key to %q: %w", keyFile, err)
+	}
+
+	return &encryptionConfig{Algorithm: encryption.Algorithm, KeyFile: keyFile}, nil
+}
+
+// decryptPath decrypts targetPath in place using the configured algorithm and key
+func (cfg *encryptionConfig) decryptPath(ctx context.Context, targetPath string) error {
+	decryptedPath := targetPath + ".decrypted"
+
+	var cmd *exec.Cmd
+	switch cfg.Algorithm {
+	case barmancloudv1.EncryptionAlgorithmAge:
+		cmd = exec.Command("age", "--decrypt", "-i", cfg.KeyFile, "-o", decryptedPath, targetPath) // #nosec G204
+	case barmancloudv1.EncryptionAlgorithmGPG:
+		cmd = exec.Command( // #nosec G204
+			"gpg", "--batch", "--yes", "--decrypt-files",
+			"--output", decryptedPath, targetPath)
+	default:
+		return fmt.Errorf("unsupported encryption algorithm: %q", cfg.Algorithm)
+	}
+
+	if err := execlog.RunStreaming(cmd, cmd.Args[0]); err != nil {
+		return fmt.Errorf("while decrypting %q: %w", targetPath, err)
+	}
+
+	return os.Rename(decryptedPath, targetPath)
+}
+
+// decryptDataDir walks pgDataPath decrypting every regular file restored from
+// an encrypted backup, using a bounded pool of workers so large PGDATA trees
+// don't decrypt one file at a time.
+//
+// Symlinks (most notably pg_tblspc/* entries pointing at tablespace
+// directories) are skipped on purpose: running age/gpg through a symlink and
+// renaming the decrypted output over it would replace the symlink itself
+// with a plain file, destroying the tablespace mapping.
+func (cfg *encryptionConfig) decryptDataDir(ctx context.Context, pgDataPath string) error {
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Info("decrypting restored data directory", "algorithm", cfg.Algorithm)
+
+	paths := make(chan string)
+	errs := make(chan error, decryptConcurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < decryptConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for walkedPath := range paths {
+				if err := cfg.decryptPath(ctx, walkedPath); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	walkErr := filepath.Walk(pgDataPath, func(walkedPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		select {
+		case paths <- walkedPath:
+			return nil
+		case err := <-errs:
+			return err
+		}
+	})
+
+	close(paths)
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+	}
+
+	return walkErr
+}
+
+// wrapWalRestoreCommand appends, to the given barman-cloud-wal-restore command
+// line, the shell pipeline that decrypts the retrieved WAL segment in place
+// before PostgreSQL reads it from %p.
+//
+// PostgreSQL itself invokes restore_command through a single "/bin/sh -c", so
+// the whole "download && decrypt && mv" pipeline must be handed to postgresql.conf
+// as one shell command line, not wrapped in a nested "bash -c '...'" that would
+// get word-split again once re-joined into the surrounding restore_command string.
+func (cfg *encryptionConfig) wrapWalRestoreCommand(cmd string) string {
+	switch cfg.Algorithm {
+	case barmancloudv1.EncryptionAlgorithmAge:
+		return fmt.Sprintf(
+			"%s && age --decrypt -i %s -o %%p.decrypted %%p && mv %%p.decrypted %%p",
+			cmd, cfg.KeyFile)
+	case barmancloudv1.EncryptionAlgorithmGPG:
+		return fmt.Sprintf(
+			"%s && gpg --batch --yes --decrypt-files --output %%p.decrypted %%p && mv %%p.decrypted %%p",
+			cmd)
+	default:
+		return cmd
+	}
+}
@@ -0,0 +1,95 @@
+package restore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	barmancloudv1 "github.com/cloudnative-pg/plugin-barman-cloud/api/v1"
+)
+
+func TestWrapWalRestoreCommand(t *testing.T) {
+	const barmanCmd = "barman-cloud-wal-restore s3://bucket server-name 000000010000000000000003 %p"
+
+	cases := []struct {
+		name      string
+		algorithm barmancloudv1.EncryptionAlgorithm
+		keyFile   string
+		expected  string
+	}{
+		{
+			name:      "age",
+			algorithm: barmancloudv1.EncryptionAlgorithmAge,
+			keyFile:   "/tmp/key.age",
+			expected: barmanCmd +
+				" && age --decrypt -i /tmp/key.age -o %p.decrypted %p && mv %p.decrypted %p",
+		},
+		{
+			name:      "gpg",
+			algorithm: barmancloudv1.EncryptionAlgorithmGPG,
+			keyFile:   "/tmp/key.gpg",
+			expected: barmanCmd +
+				" && gpg --batch --yes --decrypt-files --output %p.decrypted %p && mv %p.decrypted %p",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := &encryptionConfig{Algorithm: c.algorithm, KeyFile: c.keyFile}
+			got := cfg.wrapWalRestoreCommand(barmanCmd)
+			if got != c.expected {
+				t.Errorf("wrapWalRestoreCommand(...) = %q, expected %q", got, c.expected)
+			}
+			// The whole pipeline must remain a single shell command line: PostgreSQL
+			// already invokes restore_command via "/bin/sh -c", so a nested
+			// "bash -c '...'" would get word-split once re-joined into it.
+			if strings.Contains(got, "bash -c") {
+				t.Errorf("wrapWalRestoreCommand(...) must not nest another shell invocation, got %q", got)
+			}
+		})
+	}
+}
+
+func TestDecryptDataDirSkipsSymlinks(t *testing.T) {
+	pgData := t.TempDir()
+
+	regularFile := filepath.Join(pgData, "PG_VERSION")
+	if err := os.WriteFile(regularFile, []byte("16"), 0o600); err != nil {
+		t.Fatalf("while writing fixture: %v", err)
+	}
+
+	tablespaceTarget := t.TempDir()
+	tblspcDir := filepath.Join(pgData, "pg_tblspc")
+	if err := os.Mkdir(tblspcDir, 0o700); err != nil {
+		t.Fatalf("while creating pg_tblspc: %v", err)
+	}
+	symlinkPath := filepath.Join(tblspcDir, "16384")
+	if err := os.Symlink(tablespaceTarget, symlinkPath); err != nil {
+		t.Fatalf("while creating tablespace symlink: %v", err)
+	}
+
+	// Use a bogus algorithm so decryptPath would fail loudly if it were ever
+	// invoked on the symlink: the test only passes if the walk skips it.
+	cfg := &encryptionConfig{Algorithm: "unsupported", KeyFile: "irrelevant"}
+
+	// decryptPath on the regular file would also fail with the bogus
+	// algorithm, so remove it from the tree for this test - only the
+	// symlink-skipping behavior is under test here.
+	if err := os.Remove(regularFile); err != nil {
+		t.Fatalf("while removing fixture: %v", err)
+	}
+
+	if err := cfg.decryptDataDir(context.Background(), pgData); err != nil {
+		t.Fatalf("decryptDataDir should skip the tablespace symlink, got error: %v", err)
+	}
+
+	info, err := os.Lstat(symlinkPath)
+	if err != nil {
+		t.Fatalf("while statting symlink: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Error("decryptDataDir replaced the tablespace symlink with a regular file")
+	}
+}
@@ -0,0 +1,182 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	barmancloudv1 "github.com/cloudnative-pg/plugin-barman-cloud/api/v1"
+)
+
+// restoreHookPhase identifies one of the points in the restore flow where a
+// user-declared command can be executed
+type restoreHookPhase string
+
+const (
+	// hookPhasePreDownload runs before the backup catalog is read and any data
+	// is transferred from the object store
+	hookPhasePreDownload restoreHookPhase = "pre-download"
+
+	// hookPhasePostDatadir runs right after PGDATA (and the WAL volume, if any)
+	// have been restored, before WAL replay is configured
+	hookPhasePostDatadir restoreHookPhase = "post-datadir"
+
+	// hookPhasePreWalConfig runs right before the restore_command is generated
+	hookPhasePreWalConfig restoreHookPhase = "pre-wal-config"
+
+	// hookPhasePostRestore runs right before the restore response is sent back
+	hookPhasePostRestore restoreHookPhase = "post-restore"
+)
+
+// hookJobPollInterval is how often a hook Job's status is polled
+const hookJobPollInterval = 2 * time.Second
+
+// hookJobTimeout bounds how long the restore waits for a single Job-based hook
+// to finish; the Job itself is not stopped when the wait times out
+const hookJobTimeout = 30 * time.Minute
+
+// hookForPhase returns the command declared for the given phase, or nil when
+// none was declared
+func hookForPhase(
+	hooks *barmancloudv1.HooksConfiguration,
+	phase restoreHookPhase,
+) *barmancloudv1.RestoreHookCommand {
+	switch phase {
+	case hookPhasePreDownload:
+		return hooks.PreDownload
+	case hookPhasePostDatadir:
+		return hooks.PostDatadir
+	case hookPhasePreWalConfig:
+		return hooks.PreWalConfig
+	case hookPhasePostRestore:
+		return hooks.PostRestore
+	default:
+		return nil
+	}
+}
+
+// runHook executes, if declared on the target ObjectStore, the command for
+// the given phase. A hook with an Image set runs as a Kubernetes Job (with
+// EnvFrom honored); otherwise it runs in-process, inheriting env. A hook
+// failure aborts the restore unless hooks.FailurePolicy is
+// barmancloudv1.HookFailurePolicyContinue, in which case it is logged and the
+// restore proceeds.
+func (impl *JobHookImpl) runHook(
+	ctx context.Context,
+	cluster *cnpgv1.Cluster,
+	hooks *barmancloudv1.HooksConfiguration,
+	phase restoreHookPhase,
+	env []string,
+) error {
+	if hooks == nil {
+		return nil
+	}
+
+	hook := hookForPhase(hooks, phase)
+	if hook == nil {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	contextLogger.Info("running restore hook", "phase", phase, "image", hook.Image)
+
+	var err error
+	if hook.Image != "" {
+		err = impl.runHookJob(ctx, cluster, phase, hook)
+	} else {
+		err = runHookCommand(hook, env, phase)
+	}
+
+	if err != nil {
+		if hooks.FailurePolicy == barmancloudv1.HookFailurePolicyContinue {
+			contextLogger.Error(err, "restore hook failed, continuing because of failurePolicy",
+				"phase", phase)
+			return nil
+		}
+
+		return fmt.Errorf("restore hook for phase %q failed: %w", phase, err)
+	}
+
+	return nil
+}
+
+// runHookCommand executes hook.Command in-process, inheriting env
+func runHookCommand(hook *barmancloudv1.RestoreHookCommand, env []string, phase restoreHookPhase) error {
+	cmd := exec.Command(hook.Command[0], hook.Command[1:]...) // #nosec G204
+	cmd.Env = env
+	return execlog.RunStreaming(cmd, fmt.Sprintf("restore-hook-%s", phase))
+}
+
+// runHookJob runs hook.Command in a Job using hook.Image, sourcing its
+// environment from hook.EnvFrom, and blocks until the Job finishes
+func (impl *JobHookImpl) runHookJob(
+	ctx context.Context,
+	cluster *cnpgv1.Cluster,
+	phase restoreHookPhase,
+	hook *barmancloudv1.RestoreHookCommand,
+) error {
+	backoffLimit := int32(0)
+	ttlSecondsAfterFinished := int32(hookJobTimeout.Seconds())
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-hook-%s-", cluster.Name, phase),
+			Namespace:    cluster.Namespace,
+			Labels: map[string]string{
+				"cnpg.io/cluster": cluster.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttlSecondsAfterFinished,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "hook",
+							Image:   hook.Image,
+							Command: hook.Command,
+							EnvFrom: hook.EnvFrom,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := impl.Client.Create(ctx, job); err != nil {
+		return fmt.Errorf("while creating hook job: %w", err)
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, hookJobTimeout)
+	defer cancel()
+
+	return wait.PollUntilContextCancel(jobCtx, hookJobPollInterval, true, func(ctx context.Context) (bool, error) {
+		var current batchv1.Job
+		if err := impl.Client.Get(ctx, client.ObjectKeyFromObject(job), &current); err != nil {
+			return false, err
+		}
+
+		for _, cond := range current.Status.Conditions {
+			switch {
+			case cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue:
+				return true, nil
+			case cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue:
+				return false, fmt.Errorf("hook job %q failed: %s", job.Name, cond.Message)
+			}
+		}
+
+		return false, nil
+	})
+}
@@ -0,0 +1,76 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// restoreProgressEventInterval is how often a heartbeat Event is posted
+// while a long-running restore step (e.g. barman-cloud-restore) is in
+// flight, so operators can watch progress via "kubectl get events" instead
+// of having to tail the restore job's logs.
+//
+// This cnpg-i tree doesn't vendor the gRPC progress-streaming proto, so
+// Events are used as the reporting channel instead of a streaming RPC.
+const restoreProgressEventInterval = 30 * time.Second
+
+// restoreProgressEventReason is the Reason set on every heartbeat Event
+// emitted during the restore
+const restoreProgressEventReason = "RestoreInProgress"
+
+// reportRestoreProgress emits a heartbeat Event against the cluster being
+// restored every restoreProgressEventInterval, until done is closed
+func (impl JobHookImpl) reportRestoreProgress(ctx context.Context, step string, done <-chan struct{}) {
+	contextLogger := log.FromContext(ctx)
+	start := time.Now()
+
+	ticker := time.NewTicker(restoreProgressEventInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			message := fmt.Sprintf("%s still in progress (%s elapsed)", step, time.Since(start).Round(time.Second))
+			if err := impl.emitProgressEvent(ctx, message); err != nil {
+				contextLogger.Error(err, "while emitting restore progress event")
+			}
+		}
+	}
+}
+
+// emitProgressEvent posts a single heartbeat Event against the cluster
+// being restored
+func (impl JobHookImpl) emitProgressEvent(ctx context.Context, message string) error {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "restore-progress-",
+			Namespace:    impl.ClusterObjectKey.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: cnpgv1.GroupVersion.String(),
+			Kind:       "Cluster",
+			Namespace:  impl.ClusterObjectKey.Namespace,
+			Name:       impl.ClusterObjectKey.Name,
+		},
+		Reason:         restoreProgressEventReason,
+		Message:        message,
+		Type:           corev1.EventTypeNormal,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Source: corev1.EventSource{
+			Component: "barman-cloud.cloudnative-pg.io",
+		},
+	}
+
+	return impl.Client.Create(ctx, event)
+}
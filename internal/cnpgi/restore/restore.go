@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/cloudnative-pg/barman-cloud/pkg/api"
@@ -112,11 +113,25 @@ func (impl JobHookImpl) Restore(
 	// Before starting the restore we check if the archive destination is safe to use,
 	// otherwise we stop creating the cluster
 	if targetObjectStoreName.Name != "" {
-		if err := impl.checkBackupDestination(ctx, &cluster, &targetObjectStore.Spec.Configuration); err != nil {
+		if err := impl.checkBackupDestination(
+			ctx,
+			&cluster,
+			&targetObjectStore,
+			&recoveryObjectStore.Spec.Configuration,
+		); err != nil {
 			return nil, err
 		}
 	}
 
+	if err := impl.runHook(ctx, &cluster, targetObjectStore.Spec.Hooks, hookPhasePreDownload, os.Environ()); err != nil {
+		return nil, err
+	}
+
+	encryption, err := loadEncryptionConfig(ctx, impl.Client, cluster.Namespace, recoveryObjectStore.Spec.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	// Detect the backup to recover
 	backup, env, err := loadBackupObjectFromExternalCluster(
 		ctx,
@@ -133,30 +148,56 @@ func (impl JobHookImpl) Restore(
 		env,
 		backup,
 		&recoveryObjectStore.Spec.Configuration,
+		encryption,
 	); err != nil {
 		return nil, err
 	}
 
-	if err := impl.restoreDataDir(
-		ctx,
-		backup,
-		env,
-		&recoveryObjectStore.Spec.Configuration,
-	); err != nil {
+	if isRecoveringFromVolumeSnapshot(&cluster) {
+		// PGDATA (and, when declared, the WAL volume) have already been provisioned
+		// by the CSI driver from the VolumeSnapshot references on the recovery
+		// source. We only need to make sure PGDATA matches the backup we are about
+		// to replay WALs from, the object store is still used for PITR below.
+		if err := impl.restoreDataDirFromSnapshot(ctx, &cluster, backup); err != nil {
+			return nil, err
+		}
+	} else {
+		restoreJobs := parseRestoreJobs(recoveryPluginConfiguration.Parameters["restoreJobs"])
+		if err := impl.restoreDataDir(
+			ctx,
+			backup,
+			env,
+			&recoveryObjectStore.Spec.Configuration,
+			restoreJobs,
+			encryption,
+		); err != nil {
+			return nil, err
+		}
+
+		if cluster.Spec.WalStorage != nil {
+			if _, err := impl.restoreCustomWalDir(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := impl.runHook(ctx, &cluster, targetObjectStore.Spec.Hooks, hookPhasePostDatadir, env); err != nil {
 		return nil, err
 	}
 
-	if cluster.Spec.WalStorage != nil {
-		if _, err := impl.restoreCustomWalDir(ctx); err != nil {
-			return nil, err
-		}
+	if err := impl.runHook(ctx, &cluster, targetObjectStore.Spec.Hooks, hookPhasePreWalConfig, env); err != nil {
+		return nil, err
 	}
 
-	config, err := getRestoreWalConfig(ctx, backup, &recoveryObjectStore.Spec.Configuration)
+	config, err := getRestoreWalConfig(ctx, backup, &recoveryObjectStore.Spec.Configuration, encryption)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := impl.runHook(ctx, &cluster, targetObjectStore.Spec.Hooks, hookPhasePostRestore, env); err != nil {
+		return nil, err
+	}
+
 	contextLogger.Info("sending restore response", "config", config, "env", env)
 	return &restore.RestoreResponse{
 		RestoreConfig: config,
@@ -164,18 +205,46 @@ func (impl JobHookImpl) Restore(
 	}, nil
 }
 
-// restoreDataDir restores PGDATA from an existing backup
+// defaultRestoreJobs is the number of parallel workers used by barman-cloud-restore
+// when the recovery plugin configuration does not specify a value
+const defaultRestoreJobs = 2
+
+// parseRestoreJobs parses the "restoreJobs" recovery plugin parameter, falling back
+// to defaultRestoreJobs when it is empty or not a valid positive integer
+func parseRestoreJobs(value string) int {
+	if value == "" {
+		return defaultRestoreJobs
+	}
+
+	jobs, err := strconv.Atoi(value)
+	if err != nil || jobs <= 0 {
+		return defaultRestoreJobs
+	}
+
+	return jobs
+}
+
+// restoreDataDir restores PGDATA from an existing backup, sharding the transfer
+// across restoreJobs parallel workers via "barman-cloud-restore --jobs".
+//
+// In addition to execlog.RunStreaming's pod log output, a heartbeat Event is
+// posted against the cluster every restoreProgressEventInterval for the
+// duration of the restore (see reportRestoreProgress), so progress can also
+// be observed with "kubectl get events" without tailing the restore job.
 func (impl JobHookImpl) restoreDataDir(
 	ctx context.Context,
 	backup *cnpgv1.Backup,
 	env []string,
 	barmanConfiguration *cnpgv1.BarmanObjectStoreConfiguration,
+	restoreJobs int,
+	encryption *encryptionConfig,
 ) error {
 	var options []string
 
 	if backup.Status.EndpointURL != "" {
 		options = append(options, "--endpoint-url", backup.Status.EndpointURL)
 	}
+	options = append(options, "--jobs", strconv.Itoa(restoreJobs))
 	options = append(options, backup.Status.DestinationPath)
 	options = append(options, backup.Status.ServerName)
 	options = append(options, backup.Status.BackupID)
@@ -188,11 +257,15 @@ func (impl JobHookImpl) restoreDataDir(
 	options = append(options, impl.PgDataPath)
 
 	log.Info("Starting barman-cloud-restore",
-		"options", options)
+		"options", options, "restoreJobs", restoreJobs)
 
 	cmd := exec.Command(barmanCapabilities.BarmanCloudRestore, options...) // #nosec G204
 	cmd.Env = env
+
+	progressDone := make(chan struct{})
+	go impl.reportRestoreProgress(ctx, "barman-cloud-restore", progressDone)
 	err = execlog.RunStreaming(cmd, barmanCapabilities.BarmanCloudRestore)
+	close(progressDone)
 	if err != nil {
 		var exitError *exec.ExitError
 		if errors.As(err, &exitError) {
@@ -203,6 +276,13 @@ func (impl JobHookImpl) restoreDataDir(
 		return err
 	}
 	log.Info("Restore completed")
+
+	if encryption != nil {
+		if err := encryption.decryptDataDir(ctx, impl.PgDataPath); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -211,6 +291,7 @@ func (impl JobHookImpl) ensureArchiveContainsLastCheckpointRedoWAL(
 	env []string,
 	backup *cnpgv1.Backup,
 	barmanConfiguration *cnpgv1.BarmanObjectStoreConfiguration,
+	encryption *encryptionConfig,
 ) error {
 	// it's the full path of the file that will temporarily contain the LastCheckpointRedoWAL
 	const testWALPath = RecoveryTemporaryDirectory + "/test.wal"
@@ -240,14 +321,23 @@ func (impl JobHookImpl) ensureArchiveContainsLastCheckpointRedoWAL(
 		return fmt.Errorf("encountered an error while checking the presence of first needed WAL in the archive: %w", err)
 	}
 
+	if encryption != nil {
+		if err := encryption.decryptPath(ctx, testWALPath); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (impl *JobHookImpl) checkBackupDestination(
 	ctx context.Context,
 	cluster *cnpgv1.Cluster,
-	barmanConfiguration *cnpgv1.BarmanObjectStoreConfiguration,
+	targetObjectStore *barmancloudv1.ObjectStore,
+	recoverySourceConfiguration *cnpgv1.BarmanObjectStoreConfiguration,
 ) error {
+	barmanConfiguration := &targetObjectStore.Spec.Configuration
+
 	// Get environment from cache
 	env, err := barmanCredentials.EnvSetRestoreCloudCredentials(ctx,
 		impl.Client,
@@ -293,7 +383,65 @@ func (impl *JobHookImpl) checkBackupDestination(
 
 	// Check if we're ok to archive in the desired destination
 	if utils.IsEmptyWalArchiveCheckEnabled(&cluster.ObjectMeta) {
-		return walArchiver.CheckWalArchiveDestination(ctx, checkWalOptions)
+		if err := walArchiver.CheckWalArchiveDestination(ctx, checkWalOptions); err != nil {
+			return err
+		}
+	}
+
+	if targetObjectStore.Spec.Force {
+		return nil
+	}
+
+	// The new cluster is allowed to keep archiving into the very backup chain
+	// it is being recovered from (the common "recreate/continue this cluster"
+	// pattern): that is only the case when serverName matches the recovery
+	// source's own server name AND the target store and the recovery source
+	// store actually resolve to the same destination. Matching server names
+	// alone isn't enough: two unrelated clusters can share the same
+	// serverName convention while archiving into different, independently
+	// configured ObjectStore destinations.
+	sourceServerName, err := recoverySourceServerName(cluster)
+	if err == nil && sourceServerName == serverName &&
+		sameObjectStoreDestination(barmanConfiguration, recoverySourceConfiguration) {
+		return nil
+	}
+
+	return impl.checkNoConflictingBackupsArePresent(ctx, env, serverName, barmanConfiguration)
+}
+
+// sameObjectStoreDestination reports whether a and b point at the same
+// physical object store destination (endpoint and path), regardless of which
+// ObjectStore CR they were read from
+func sameObjectStoreDestination(a, b *cnpgv1.BarmanObjectStoreConfiguration) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	return a.DestinationPath == b.DestinationPath && a.EndpointURL == b.EndpointURL
+}
+
+// checkNoConflictingBackupsArePresent refuses the restore when the target object
+// store already holds backups for serverName, which would mean we are about to
+// archive WALs and backups for this new cluster on top of an unrelated one,
+// producing an ambiguous timeline. The caller skips it only when serverName
+// matches the recovery source's own server name and the target store resolves
+// to the same destination as the recovery source store.
+func (impl *JobHookImpl) checkNoConflictingBackupsArePresent(
+	ctx context.Context,
+	env []string,
+	serverName string,
+	barmanConfiguration *cnpgv1.BarmanObjectStoreConfiguration,
+) error {
+	existingBackups, err := barmanCommand.GetBackupList(ctx, barmanConfiguration, serverName, env)
+	if err != nil {
+		return fmt.Errorf("while checking for preexisting backups in the target object store: %w", err)
+	}
+
+	if len(existingBackups.List) > 0 {
+		return fmt.Errorf(
+			"refusing to bootstrap: the target object store already contains %d backup(s) for server %q, "+
+				"set \"force: true\" in the target ObjectStore's spec to overwrite them",
+			len(existingBackups.List), serverName)
 	}
 
 	return nil
@@ -340,6 +488,7 @@ func getRestoreWalConfig(
 	ctx context.Context,
 	backup *cnpgv1.Backup,
 	barmanConfiguration *cnpgv1.BarmanObjectStoreConfiguration,
+	encryption *encryptionConfig,
 ) (string, error) {
 	var err error
 
@@ -357,14 +506,46 @@ func getRestoreWalConfig(
 
 	cmd = append(cmd, "%f", "%p")
 
+	restoreCommand := strings.Join(cmd, " ")
+	if encryption != nil {
+		restoreCommand = encryption.wrapWalRestoreCommand(restoreCommand)
+	}
+
 	recoveryFileContents := fmt.Sprintf(
 		"recovery_target_action = promote\n"+
 			"restore_command = '%s'\n",
-		strings.Join(cmd, " "))
+		restoreCommand)
 
 	return recoveryFileContents, nil
 }
 
+// externalClusterServerName returns the barman server name to be used for an
+// external cluster, honoring the "serverName" plugin parameter override
+func externalClusterServerName(server cnpgv1.ExternalCluster) string {
+	if pluginServerName, ok := server.PluginConfiguration.Parameters["serverName"]; ok {
+		return pluginServerName
+	}
+	return server.Name
+}
+
+// recoverySourceServerName returns the barman server name of the cluster's
+// recovery source, i.e. the server name the backup catalog used by
+// loadBackupObjectFromExternalCluster is read from
+func recoverySourceServerName(cluster *cnpgv1.Cluster) (string, error) {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Recovery == nil ||
+		cluster.Spec.Bootstrap.Recovery.Source == "" {
+		return "", fmt.Errorf("recovery source not specified")
+	}
+
+	sourceName := cluster.Spec.Bootstrap.Recovery.Source
+	server, found := cluster.ExternalCluster(sourceName)
+	if !found {
+		return "", fmt.Errorf("missing external cluster: %v", sourceName)
+	}
+
+	return externalClusterServerName(server), nil
+}
+
 // loadBackupObjectFromExternalCluster generates an in-memory Backup structure given a reference to
 // an external cluster, loading the required information from the object store
 func loadBackupObjectFromExternalCluster(
@@ -385,13 +566,7 @@ func loadBackupObjectFromExternalCluster(
 		return nil, nil, fmt.Errorf("missing external cluster: %v", sourceName)
 	}
 
-	// TODO: document this, should this be in the helper?
-	var serverName string
-	if pluginServerName, ok := server.PluginConfiguration.Parameters["serverName"]; ok {
-		serverName = pluginServerName
-	} else {
-		serverName = server.Name
-	}
+	serverName := externalClusterServerName(server)
 
 	contextLogger.Info("Recovering from external cluster",
 		"sourceName", sourceName,
@@ -412,17 +587,25 @@ func loadBackupObjectFromExternalCluster(
 		return nil, nil, err
 	}
 
+	selector := parseBackupSelector(server.PluginConfiguration.Parameters["backupSelector"])
+
 	// We are now choosing the right backup to restore
 	var targetBackup *barmanCatalog.BarmanBackup
-	if cluster.Spec.Bootstrap.Recovery != nil &&
-		cluster.Spec.Bootstrap.Recovery.RecoveryTarget != nil {
+	switch {
+	case len(selector) > 0:
+		targetBackup, err = findBackupBySelector(ctx, typedClient, cluster.Namespace, selector, backupCatalog)
+		if err != nil {
+			return nil, nil, err
+		}
+	case cluster.Spec.Bootstrap.Recovery != nil &&
+		cluster.Spec.Bootstrap.Recovery.RecoveryTarget != nil:
 		targetBackup, err = backupCatalog.FindBackupInfo(
 			cluster.Spec.Bootstrap.Recovery.RecoveryTarget,
 		)
 		if err != nil {
 			return nil, nil, err
 		}
-	} else {
+	default:
 		targetBackup = backupCatalog.LatestBackupInfo()
 	}
 	if targetBackup == nil {
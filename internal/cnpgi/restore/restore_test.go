@@ -0,0 +1,155 @@
+package restore
+
+import (
+	"testing"
+
+	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+func TestParseRestoreJobs(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{"empty falls back to default", "", defaultRestoreJobs},
+		{"invalid falls back to default", "not-a-number", defaultRestoreJobs},
+		{"zero falls back to default", "0", defaultRestoreJobs},
+		{"negative falls back to default", "-3", defaultRestoreJobs},
+		{"valid value is used", "8", 8},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := parseRestoreJobs(c.value); got != c.expected {
+				t.Errorf("parseRestoreJobs(%q) = %d, expected %d", c.value, got, c.expected)
+			}
+		})
+	}
+}
+
+func TestSameObjectStoreDestination(t *testing.T) {
+	a := &cnpgv1.BarmanObjectStoreConfiguration{
+		DestinationPath: "s3://bucket/path",
+		EndpointURL:     "https://s3.example.com",
+	}
+
+	cases := []struct {
+		name     string
+		other    *cnpgv1.BarmanObjectStoreConfiguration
+		expected bool
+	}{
+		{
+			name: "same destination and endpoint",
+			other: &cnpgv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://bucket/path",
+				EndpointURL:     "https://s3.example.com",
+			},
+			expected: true,
+		},
+		{
+			name: "different destination",
+			other: &cnpgv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://other-bucket/path",
+				EndpointURL:     "https://s3.example.com",
+			},
+			expected: false,
+		},
+		{
+			name: "different endpoint",
+			other: &cnpgv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://bucket/path",
+				EndpointURL:     "https://s3.other.example.com",
+			},
+			expected: false,
+		},
+		{
+			name:     "nil other",
+			other:    nil,
+			expected: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := sameObjectStoreDestination(a, c.other); got != c.expected {
+				t.Errorf("sameObjectStoreDestination(...) = %v, expected %v", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestExternalClusterServerName(t *testing.T) {
+	cases := []struct {
+		name     string
+		server   cnpgv1.ExternalCluster
+		expected string
+	}{
+		{
+			name:     "falls back to cluster name",
+			server:   cnpgv1.ExternalCluster{Name: "source-cluster"},
+			expected: "source-cluster",
+		},
+		{
+			name: "honors serverName override",
+			server: cnpgv1.ExternalCluster{
+				Name: "source-cluster",
+				PluginConfiguration: &cnpgv1.PluginConfiguration{
+					Parameters: map[string]string{"serverName": "overridden-name"},
+				},
+			},
+			expected: "overridden-name",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := externalClusterServerName(c.server); got != c.expected {
+				t.Errorf("externalClusterServerName(...) = %q, expected %q", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestRecoverySourceServerName(t *testing.T) {
+	t.Run("no recovery source returns an error", func(t *testing.T) {
+		cluster := &cnpgv1.Cluster{}
+		if _, err := recoverySourceServerName(cluster); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+
+	t.Run("missing external cluster returns an error", func(t *testing.T) {
+		cluster := &cnpgv1.Cluster{
+			Spec: cnpgv1.ClusterSpec{
+				Bootstrap: &cnpgv1.BootstrapConfiguration{
+					Recovery: &cnpgv1.BootstrapRecovery{Source: "missing-source"},
+				},
+			},
+		}
+		if _, err := recoverySourceServerName(cluster); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+
+	t.Run("resolves the source external cluster's server name", func(t *testing.T) {
+		cluster := &cnpgv1.Cluster{
+			Spec: cnpgv1.ClusterSpec{
+				Bootstrap: &cnpgv1.BootstrapConfiguration{
+					Recovery: &cnpgv1.BootstrapRecovery{Source: "source-cluster"},
+				},
+				ExternalClusters: []cnpgv1.ExternalCluster{
+					{Name: "source-cluster"},
+				},
+			},
+		}
+
+		name, err := recoverySourceServerName(cluster)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "source-cluster" {
+			t.Errorf("recoverySourceServerName(...) = %q, expected %q", name, "source-cluster")
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	barmanCatalog "github.com/cloudnative-pg/barman-cloud/pkg/catalog"
+	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// parseBackupSelector parses the "backupSelector" external cluster plugin
+// parameter, a comma-separated list of "key=value" labels, e.g.
+// "environment=prod,app-version=1.4.2". Malformed pairs are skipped; an empty
+// value, or one without any well-formed pair, yields no selector, falling back
+// to the usual time/LSN/backupID/latest selection.
+func parseBackupSelector(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+
+	selector := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || key == "" {
+			continue
+		}
+		selector[key] = val
+	}
+
+	if len(selector) == 0 {
+		return nil
+	}
+
+	return selector
+}
+
+// findBackupBySelector resolves selector to a backup in the barman catalog.
+//
+// The barman-cloud catalog entries are built from barman's own backup_info
+// schema and don't carry arbitrary user tags, so the selector is matched
+// against the labels of the cnpgv1.Backup objects recorded in namespace
+// instead: among those matching selector, the most recently completed one
+// whose Status.BackupID is still present in the catalog is returned.
+func findBackupBySelector(
+	ctx context.Context,
+	typedClient client.Client,
+	namespace string,
+	selector map[string]string,
+	catalog *barmanCatalog.Catalog,
+) (*barmanCatalog.BarmanBackup, error) {
+	var backupList cnpgv1.BackupList
+	if err := typedClient.List(ctx, &backupList, client.InNamespace(namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("while listing backups matching selector %v: %w", selector, err)
+	}
+
+	catalogByID := make(map[string]*barmanCatalog.BarmanBackup, len(catalog.List))
+	for i := range catalog.List {
+		catalogByID[catalog.List[i].ID] = catalog.List[i]
+	}
+
+	var found *barmanCatalog.BarmanBackup
+	var foundStoppedAt *metav1.Time
+	for i := range backupList.Items {
+		candidateBackup := &backupList.Items[i]
+		candidate, ok := catalogByID[candidateBackup.Status.BackupID]
+		if !ok || candidateBackup.Status.StoppedAt == nil {
+			continue
+		}
+
+		if found == nil || candidateBackup.Status.StoppedAt.After(foundStoppedAt.Time) {
+			found = candidate
+			foundStoppedAt = candidateBackup.Status.StoppedAt
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no backup found in the catalog matching selector %v", selector)
+	}
+
+	return found, nil
+}
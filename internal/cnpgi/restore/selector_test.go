@@ -0,0 +1,106 @@
+package restore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	barmanCatalog "github.com/cloudnative-pg/barman-cloud/pkg/catalog"
+	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseBackupSelector(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected map[string]string
+	}{
+		{"empty value", "", nil},
+		{"single pair", "environment=prod", map[string]string{"environment": "prod"}},
+		{
+			"multiple pairs",
+			"environment=prod,app-version=1.4.2",
+			map[string]string{"environment": "prod", "app-version": "1.4.2"},
+		},
+		{"malformed pair is skipped", "environment=prod,malformed", map[string]string{"environment": "prod"}},
+		{"only malformed yields nil", "malformed", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := parseBackupSelector(c.value)
+			if len(got) != len(c.expected) {
+				t.Fatalf("parseBackupSelector(%q) = %v, expected %v", c.value, got, c.expected)
+			}
+			for k, v := range c.expected {
+				if got[k] != v {
+					t.Errorf("parseBackupSelector(%q)[%q] = %q, expected %q", c.value, k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestFindBackupBySelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := cnpgv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("while building scheme: %v", err)
+	}
+
+	older := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	matchingOlder := &cnpgv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "older", Namespace: "default",
+			Labels: map[string]string{"environment": "prod"},
+		},
+		Status: cnpgv1.BackupStatus{BackupID: "older-id", StoppedAt: &older},
+	}
+	matchingNewer := &cnpgv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "newer", Namespace: "default",
+			Labels: map[string]string{"environment": "prod"},
+		},
+		Status: cnpgv1.BackupStatus{BackupID: "newer-id", StoppedAt: &newer},
+	}
+	nonMatching := &cnpgv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other", Namespace: "default",
+			Labels: map[string]string{"environment": "staging"},
+		},
+		Status: cnpgv1.BackupStatus{BackupID: "other-id", StoppedAt: &newer},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(matchingOlder, matchingNewer, nonMatching).
+		Build()
+
+	catalog := &barmanCatalog.Catalog{
+		List: []*barmanCatalog.BarmanBackup{
+			{ID: "older-id"},
+			{ID: "newer-id"},
+			{ID: "other-id"},
+		},
+	}
+
+	found, err := findBackupBySelector(
+		context.Background(), cl, "default", map[string]string{"environment": "prod"}, catalog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.ID != "newer-id" {
+		t.Errorf("findBackupBySelector(...) = %q, expected the most recently stopped match %q",
+			found.ID, "newer-id")
+	}
+
+	if _, err := findBackupBySelector(
+		context.Background(), cl, "default", map[string]string{"environment": "nonexistent"}, catalog,
+	); err == nil {
+		t.Error("expected an error when no backup matches the selector, got nil")
+	}
+}
@@ -0,0 +1,108 @@
+package restore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+)
+
+// backupLabelFileName is the name of the file written by PostgreSQL inside PGDATA
+// describing the backup that originated it
+const backupLabelFileName = "backup_label"
+
+// backupLabelStartWALPrefix is the line of backup_label carrying the name of the
+// WAL file containing the backup's starting checkpoint redo record
+const backupLabelStartWALPrefix = "START WAL LOCATION"
+
+// restoreDataDirFromSnapshot verifies that the PGDATA already materialized from a
+// VolumeSnapshot by the cluster bootstrap belongs to the backup chosen for
+// recovery, by comparing the start WAL in its backup_label with the backup's
+// BeginWal. The WAL volume, when present, is not checked: only its pg_wal
+// symlink is set up through restoreCustomWalDir.
+//
+// Unlike restoreDataDir, no barman-cloud-restore invocation happens here: the
+// CSI driver has already populated the volumes from the snapshot references
+// declared on the recovery source, this function only makes sure we are not
+// about to replay WALs on top of the wrong base backup.
+//
+// Tablespace volume snapshots aren't handled yet: a cluster declaring them
+// fails fast here instead of silently skipping their consistency check.
+func (impl JobHookImpl) restoreDataDirFromSnapshot(
+	ctx context.Context,
+	cluster *cnpgv1.Cluster,
+	backup *cnpgv1.Backup,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if len(cluster.Spec.Bootstrap.Recovery.VolumeSnapshots.TablespaceStorage) > 0 {
+		return fmt.Errorf(
+			"restoring PGDATA from a VolumeSnapshot with separate tablespace snapshots is not supported yet")
+	}
+
+	startWAL, err := readBackupLabelStartWAL(path.Join(impl.PgDataPath, backupLabelFileName))
+	if err != nil {
+		return fmt.Errorf("while reading the backup label left by the volume snapshot restore: %w", err)
+	}
+
+	if startWAL != backup.Status.BeginWal {
+		return fmt.Errorf(
+			"volume snapshot restore is not consistent with the selected backup: "+
+				"backup_label reports start WAL %q, expected %q (backup id: %q)",
+			startWAL, backup.Status.BeginWal, backup.Status.BackupID)
+	}
+
+	contextLogger.Info("volume snapshot restore verified against the selected backup",
+		"backupID", backup.Status.BackupID, "startWAL", startWAL)
+
+	if cluster.Spec.WalStorage != nil {
+		if _, err := impl.restoreCustomWalDir(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBackupLabelStartWAL extracts the starting WAL file name from a backup_label
+// file, e.g. from a line such as:
+//
+//	START WAL LOCATION: 0/3000028 (file 000000010000000000000003)
+func readBackupLabelStartWAL(labelPath string) (string, error) {
+	content, err := os.ReadFile(labelPath) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, backupLabelStartWALPrefix) {
+			continue
+		}
+
+		start := strings.Index(line, "(file ")
+		end := strings.Index(line, ")")
+		if start == -1 || end == -1 || end <= start {
+			return "", fmt.Errorf("malformed %q line: %q", backupLabelStartWALPrefix, line)
+		}
+
+		return line[start+len("(file ") : end], nil
+	}
+
+	return "", fmt.Errorf("%q line not found in backup label", backupLabelStartWALPrefix)
+}
+
+// isRecoveringFromVolumeSnapshot returns true when the cluster bootstrap declares
+// VolumeSnapshot references to restore PGDATA from, instead of the object store
+func isRecoveringFromVolumeSnapshot(cluster *cnpgv1.Cluster) bool {
+	return cluster.Spec.Bootstrap != nil &&
+		cluster.Spec.Bootstrap.Recovery != nil &&
+		cluster.Spec.Bootstrap.Recovery.VolumeSnapshots != nil
+}
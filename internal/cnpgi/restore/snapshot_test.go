@@ -0,0 +1,54 @@
+package restore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadBackupLabelStartWAL(t *testing.T) {
+	t.Run("valid backup_label", func(t *testing.T) {
+		labelPath := filepath.Join(t.TempDir(), "backup_label")
+		content := "START WAL LOCATION: 0/3000028 (file 000000010000000000000003)\n" +
+			"CHECKPOINT LOCATION: 0/3000060\n"
+		if err := os.WriteFile(labelPath, []byte(content), 0o600); err != nil {
+			t.Fatalf("while writing fixture: %v", err)
+		}
+
+		startWAL, err := readBackupLabelStartWAL(labelPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := "000000010000000000000003"; startWAL != expected {
+			t.Errorf("readBackupLabelStartWAL(...) = %q, expected %q", startWAL, expected)
+		}
+	})
+
+	t.Run("missing START WAL LOCATION line", func(t *testing.T) {
+		labelPath := filepath.Join(t.TempDir(), "backup_label")
+		if err := os.WriteFile(labelPath, []byte("CHECKPOINT LOCATION: 0/3000060\n"), 0o600); err != nil {
+			t.Fatalf("while writing fixture: %v", err)
+		}
+
+		if _, err := readBackupLabelStartWAL(labelPath); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+
+	t.Run("malformed START WAL LOCATION line", func(t *testing.T) {
+		labelPath := filepath.Join(t.TempDir(), "backup_label")
+		if err := os.WriteFile(labelPath, []byte("START WAL LOCATION: 0/3000028\n"), 0o600); err != nil {
+			t.Fatalf("while writing fixture: %v", err)
+		}
+
+		if _, err := readBackupLabelStartWAL(labelPath); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := readBackupLabelStartWAL(filepath.Join(t.TempDir(), "does-not-exist")); err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+}